@@ -10,6 +10,13 @@ import (
 
 	"github.com/go-gota/gota/dataframe"
 	"github.com/go-gota/gota/series"
+
+	"managed_etf/src/config"
+	"managed_etf/src/optimize"
+	"managed_etf/src/portfolio"
+	"managed_etf/src/report"
+	"managed_etf/src/stats"
+	"managed_etf/src/strategies"
 )
 
 // Trade represents a single buy and sell transaction.
@@ -21,14 +28,17 @@ type Trade struct {
 	ExitPrice  float64
 	ProfitLoss float64 // This is now a percentage
 	ProfitLossAbs float64 // New field for absolute dollar value
+	DividendsCollected float64 // Dividends credited while the position was open
 }
 
 // Global constants for the trading strategy
 const (
-	shortMALength = 50  // Short-term moving average period (50 days)
-	longMALength  = 200 // Long-term moving average period (200 days)
-	unitSize      = 1000.0 // The dollar amount per trading unit
 	initialCapital = 10000.0 // The starting capital for the portfolio
+
+	profitMAWindow        = 60 // Window (in bars) for the accumulated-profit SMA in the profit report
+	shortTermProfitWindow = 14 // Window (in bars) for the short-term accumulated-profit SMA in the profit report
+
+	configPath = "../config.yaml" // Path to the YAML file selecting the strategy and its parameters
 )
 
 // readCSV reads stock data from a specified CSV file into a dataframe.
@@ -51,131 +61,186 @@ func readCSV(filePath string) (dataframe.DataFrame, error) {
 	return df, nil
 }
 
-// calculateMovingAverage computes the simple moving average for a given window using the Close price.
-// This function uses a simple sliding window loop, which is a robust way to calculate SMA.
-func calculateMovingAverage(dataSeries series.Series, window int) series.Series {
-	if dataSeries.Len() < window {
-		return series.New([]float64{}, series.Float, "SMA")
+// loadDividends reads <dataDir>/<ticker>_dividends.csv (columns ex_date,amount)
+// into a map of ex-dividend date to per-share amount. A missing file is not
+// an error: most tickers simply have no dividend history on disk, so callers
+// get back an empty map instead of having to special-case os.IsNotExist.
+func loadDividends(dataDir, ticker string) (map[string]float64, error) {
+	path := filepath.Join(dataDir, ticker+"_dividends.csv")
+	file, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return map[string]float64{}, nil
+		}
+		return nil, fmt.Errorf("could not open dividends file: %w", err)
 	}
+	defer file.Close()
 
-	var sma []float64
-	// Initialize with zeros for the period before the first full window
-	for i := 0; i < window-1; i++ {
-		sma = append(sma, 0.0)
+	df := dataframe.ReadCSV(file)
+	dividends := make(map[string]float64, df.Nrow())
+	exDates := df.Col("ex_date")
+	amounts := df.Col("amount")
+	for i := 0; i < df.Nrow(); i++ {
+		dividends[exDates.Elem(i).String()] = amounts.Elem(i).Float()
 	}
+	return dividends, nil
+}
 
-	var sum float64
-	// Calculate the sum for the first window
-	for i := 0; i < window; i++ {
-		sum += dataSeries.Elem(i).Float()
-	}
-	sma = append(sma, sum/float64(window))
+// tickerState bundles one ticker's price data, strategy and profit tracker
+// so runPortfolioBacktest can advance every ticker in lock-step by date.
+type tickerState struct {
+	Ticker   string
+	Strategy strategies.Strategy
+	Tracker  *report.ProfitTracker
 
-	// Slide the window and update the sum for subsequent averages
-	for i := window; i < dataSeries.Len(); i++ {
-		sum += dataSeries.Elem(i).Float() - dataSeries.Elem(i-window).Float()
-		sma = append(sma, sum/float64(window))
-	}
+	dateSeries  series.Series
+	openSeries  series.Series
+	closeSeries series.Series
+	atrSeries   series.Series // only populated when HIGH/LOW columns are present
+	hasATR      bool
 
-	return series.New(sma, series.Float, "SMA")
+	dateToIndex map[string]int
+	warmupBars  int
+
+	Dividends map[string]float64 // ex-dividend date -> per-share amount
 }
 
-// backtestStrategy runs the moving average crossover algorithm and returns a summary of trades.
-func backtestStrategy(df dataframe.DataFrame, ticker string) []Trade {
-	// Check for required columns before proceeding. The names are all caps.
-	// We'll check if the column names exist in the dataframe's list of names.
+// newTickerState validates df's required columns, initializes strat against
+// it, and indexes its bars by date for lock-step iteration.
+func newTickerState(ticker string, df dataframe.DataFrame, strat strategies.Strategy, tracker *report.ProfitTracker, atrWindow int, dividends map[string]float64) (*tickerState, error) {
 	requiredCols := []string{"DATE", "OPEN", "CLOSE"}
-	dfColNames := df.Names()
 	colMap := make(map[string]bool)
-	for _, name := range dfColNames {
+	for _, name := range df.Names() {
 		colMap[name] = true
 	}
-
 	for _, col := range requiredCols {
 		if !colMap[col] {
-			fmt.Printf("Error: required column '%s' not found in dataframe. Skipping backtest for this stock.\n", col)
-			return nil
+			return nil, fmt.Errorf("required column '%s' not found in dataframe", col)
 		}
 	}
 
-	if df.Nrow() < longMALength {
-		fmt.Println("Not enough data to perform backtest.")
-		return nil
+	if err := strat.Init(df); err != nil {
+		return nil, fmt.Errorf("could not initialize strategy: %w", err)
+	}
+
+	ts := &tickerState{
+		Ticker:      ticker,
+		Strategy:    strat,
+		Tracker:     tracker,
+		dateSeries:  df.Col("DATE"),
+		openSeries:  df.Col("OPEN"),
+		closeSeries: df.Col("CLOSE"),
+		dateToIndex: make(map[string]int, df.Nrow()),
+		warmupBars:  strat.WarmupBars(),
+		Dividends:   dividends,
+	}
+	if colMap["HIGH"] && colMap["LOW"] {
+		ts.atrSeries = strategies.ATR(df.Col("HIGH"), df.Col("LOW"), ts.closeSeries, atrWindow)
+		ts.hasATR = true
+	}
+	for i := 0; i < df.Nrow(); i++ {
+		ts.dateToIndex[ts.dateSeries.Elem(i).String()] = i
 	}
 
-	// Get the 'CLOSE', 'OPEN', and 'DATE' price series for our calculations
-	closePriceSeries := df.Col("CLOSE")
-	openPriceSeries := df.Col("OPEN")
-	dateSeries := df.Col("DATE")
+	return ts, nil
+}
 
-	// Calculate the moving averages
-	shortMA := calculateMovingAverage(closePriceSeries, shortMALength)
-	longMA := calculateMovingAverage(closePriceSeries, longMALength)
+func (ts *tickerState) atrAt(i int) float64 {
+	if !ts.hasATR {
+		return 0
+	}
+	return ts.atrSeries.Elem(i).Float()
+}
 
+// runPortfolioBacktest advances every ticker in states in lock-step across
+// the union of their trade dates, routing every Buy/Sell signal through pf so
+// position sizing, cash constraints and the daily equity curve reflect the
+// whole portfolio rather than isolated per-ticker backtests.
+func runPortfolioBacktest(states map[string]*tickerState, dates []string, pf *portfolio.Portfolio) []Trade {
 	var trades []Trade
-	var currentTrade *Trade = nil
-
-	// Start iterating after the long MA has enough data to be calculated
-	for i := longMALength; i < df.Nrow(); i++ {
-		// Get values for current and previous day
-		currentShortMA := shortMA.Elem(i).Float()
-		prevShortMA := shortMA.Elem(i-1).Float()
-		currentLongMA := longMA.Elem(i).Float()
-		prevLongMA := longMA.Elem(i-1).Float()
-
-		// Buy signal: short MA crosses above long MA
-		if currentShortMA > currentLongMA && prevShortMA <= prevLongMA {
-			if currentTrade == nil {
-				entryPrice := openPriceSeries.Elem(i).Float()
-				entryDate := dateSeries.Elem(i).String()
-				currentTrade = &Trade{
-					Ticker:     ticker,
-					EntryDate:  entryDate,
-					EntryPrice: entryPrice,
-				}
-			}
-		}
+	lastKnownPrice := make(map[string]float64, len(states))
 
-		// Sell signal: short MA crosses below long MA
-		if currentShortMA < currentLongMA && prevShortMA >= prevLongMA {
-			if currentTrade != nil {
-				exitPrice := openPriceSeries.Elem(i).Float()
-				exitDate := dateSeries.Elem(i).String()
+	for _, date := range dates {
+		for ticker, ts := range states {
+			i, ok := ts.dateToIndex[date]
+			if !ok {
+				continue
+			}
+			lastKnownPrice[ticker] = ts.closeSeries.Elem(i).Float()
 
-				// Calculate number of shares
-				numShares := unitSize / currentTrade.EntryPrice
+			if amount, ok := ts.Dividends[date]; ok {
+				pf.CreditDividend(ticker, amount)
+			}
 
-				// Calculate absolute profit/loss
-				profit := (exitPrice - currentTrade.EntryPrice) * numShares
+			if i >= ts.warmupBars {
+				switch ts.Strategy.OnBar(i) {
+				case strategies.Buy:
+					price := ts.openSeries.Elem(i).Float()
+					pf.Buy(ticker, date, price, ts.atrAt(i))
+
+				case strategies.Sell:
+					price := ts.openSeries.Elem(i).Float()
+					if closed, ok := pf.Sell(ticker, date, price); ok {
+						trades = append(trades, Trade{
+							Ticker:             closed.Ticker,
+							EntryDate:          closed.EntryDate,
+							ExitDate:           closed.ExitDate,
+							EntryPrice:         closed.EntryPrice,
+							ExitPrice:          closed.ExitPrice,
+							ProfitLoss:         closed.ProfitLoss,
+							ProfitLossAbs:      closed.ProfitLossAbs,
+							DividendsCollected: closed.DividendsCollected,
+						})
+						if ts.Tracker != nil {
+							ts.Tracker.Record(closed.ProfitLossAbs, 0)
+						}
+					}
+				}
+			}
 
-				currentTrade.ExitDate = exitDate
-				currentTrade.ExitPrice = exitPrice
-				currentTrade.ProfitLoss = (exitPrice - currentTrade.EntryPrice) / currentTrade.EntryPrice
-				currentTrade.ProfitLossAbs = profit
-				trades = append(trades, *currentTrade)
-				currentTrade = nil // Reset for the next trade
+			// Rotate every bar, including warmup, so the report covers the
+			// whole backtest instead of silently starting partway through.
+			if ts.Tracker != nil {
+				ts.Tracker.Rotate(date)
 			}
 		}
+
+		pf.MarkToMarket(date, lastKnownPrice)
 	}
 
-	// Close any open trades at the end of the data
-	if currentTrade != nil {
-		exitPrice := openPriceSeries.Elem(df.Nrow() - 1).Float()
-		exitDate := dateSeries.Elem(df.Nrow() - 1).String()
-		numShares := unitSize / currentTrade.EntryPrice
-		profit := (exitPrice - currentTrade.EntryPrice) * numShares
-
-		currentTrade.ExitDate = exitDate
-		currentTrade.ExitPrice = exitPrice
-		currentTrade.ProfitLoss = (exitPrice - currentTrade.EntryPrice) / currentTrade.EntryPrice
-		currentTrade.ProfitLossAbs = profit
-		trades = append(trades, *currentTrade)
+	// Liquidate any positions still open at the last known price for their ticker.
+	for ticker, ts := range states {
+		price, ok := lastKnownPrice[ticker]
+		if !ok {
+			continue
+		}
+		if closed, ok := pf.Sell(ticker, dates[len(dates)-1], price); ok {
+			trades = append(trades, Trade{
+				Ticker:             closed.Ticker,
+				EntryDate:          closed.EntryDate,
+				ExitDate:           closed.ExitDate,
+				EntryPrice:         closed.EntryPrice,
+				ExitPrice:          closed.ExitPrice,
+				ProfitLoss:         closed.ProfitLoss,
+				ProfitLossAbs:      closed.ProfitLossAbs,
+				DividendsCollected: closed.DividendsCollected,
+			})
+			if ts.Tracker != nil {
+				ts.Tracker.Record(closed.ProfitLossAbs, 0)
+			}
+		}
 	}
 
 	return trades
 }
 
-// findCSVs searches a directory for files ending in .csv and returns their full paths.
+// dividendsSuffix marks a dividends-history file (<ticker>_dividends.csv),
+// which findCSVs must not treat as its own price-data series.
+const dividendsSuffix = "_dividends.csv"
+
+// findCSVs searches a directory for price-data CSV files and returns their
+// full paths, skipping dividend-history files (*_dividends.csv) since those
+// are loaded separately by loadDividends rather than backtested directly.
 func findCSVs(dirPath string) ([]string, error) {
 	files, err := os.ReadDir(dirPath)
 	if err != nil {
@@ -184,16 +249,105 @@ func findCSVs(dirPath string) ([]string, error) {
 
 	var csvFiles []string
 	for _, file := range files {
-		if !file.IsDir() && filepath.Ext(file.Name()) == ".csv" {
-			csvFiles = append(csvFiles, filepath.Join(dirPath, file.Name()))
+		if file.IsDir() || filepath.Ext(file.Name()) != ".csv" {
+			continue
+		}
+		if strings.HasSuffix(file.Name(), dividendsSuffix) {
+			continue
 		}
+		csvFiles = append(csvFiles, filepath.Join(dirPath, file.Name()))
 	}
 	return csvFiles, nil
 }
 
+// toStatsTrades converts backtest trades into the minimal shape the stats
+// package needs to compute its metrics.
+func toStatsTrades(trades []Trade) []stats.Trade {
+	statsTrades := make([]stats.Trade, len(trades))
+	for i, trade := range trades {
+		statsTrades[i] = stats.Trade{
+			EntryDate:     trade.EntryDate,
+			ExitDate:      trade.ExitDate,
+			ProfitLoss:    trade.ProfitLoss,
+			ProfitLossAbs: trade.ProfitLossAbs,
+		}
+	}
+	return statsTrades
+}
+
+// printTradeStats prints the full trade-statistics summary to stdout.
+func printTradeStats(ts stats.TradeStats) {
+	fmt.Printf("Gross Profit: $%.2f | Gross Loss: $%.2f | Profit Factor: %.2f\n", ts.GrossProfit, ts.GrossLoss, ts.ProfitFactor)
+	fmt.Printf("Average Win: $%.2f | Average Loss: $%.2f | Expectancy: $%.2f\n", ts.AverageWin, ts.AverageLoss, ts.Expectancy)
+	fmt.Printf("Max Consecutive Wins: %d | Max Consecutive Losses: %d\n", ts.MaxConsecutiveWins, ts.MaxConsecutiveLosses)
+	fmt.Printf("Sharpe Ratio: %.2f | Sortino Ratio: %.2f | Calmar Ratio: %.2f\n", ts.SharpeRatio, ts.SortinoRatio, ts.Calmar)
+	fmt.Printf("Max Drawdown: %.2f%% over %d trades | CAGR: %.2f%%\n", ts.MaxDrawdown*100, ts.MaxDrawdownDuration, ts.CAGR*100)
+}
+
+// runOptimize implements the `optimize` subcommand: for every ticker's CSV it
+// walk-forward optimizes cfg.Strategy's moving-average windows per
+// cfg.Optimize's train/test geometry and objective, then prints the
+// in-sample-vs-out-of-sample comparison for every window plus the combined
+// out-of-sample trade statistics.
+func runOptimize() {
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		fmt.Printf("Error loading config: %v\n", err)
+		return
+	}
+
+	dataDir := filepath.Join("..", cfg.DataDir)
+	files, err := findCSVs(dataDir)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	for _, filePath := range files {
+		baseName := filepath.Base(filePath)
+		ticker := strings.TrimSuffix(baseName, "_yahoo_finance.csv")
+
+		df, err := readCSV(filePath)
+		if err != nil {
+			fmt.Printf("Error reading data for %s: %v\n", ticker, err)
+			continue
+		}
+
+		optReport, err := optimize.Run(ticker, df, cfg.Strategy, cfg.Params, cfg.Optimize)
+		if err != nil {
+			fmt.Printf("Error optimizing %s: %v\n", ticker, err)
+			continue
+		}
+
+		fmt.Printf("\n--- Walk-Forward Optimization for %s ---\n", ticker)
+		for i, window := range optReport.Windows {
+			degradation := window.InSample.SharpeRatio - window.OutOfSample.SharpeRatio
+			fmt.Printf("Window %d: train [%d:%d) -> test [%d:%d) | best short=%d long=%d | in-sample Sharpe %.2f | out-of-sample Sharpe %.2f | degradation %.2f\n",
+				i, window.TrainStart, window.TrainEnd, window.TestStart, window.TestEnd,
+				window.BestParams.ShortWindow, window.BestParams.LongWindow,
+				window.InSample.SharpeRatio, window.OutOfSample.SharpeRatio, degradation)
+		}
+		fmt.Println("Combined out-of-sample statistics:")
+		printTradeStats(optReport.Combined)
+		fmt.Println("----------------------------------------")
+	}
+}
+
 func main() {
-	// The path to your directory containing the CSV files.
-	const dataDir = "../collected_data"
+	if len(os.Args) > 1 && os.Args[1] == "optimize" {
+		runOptimize()
+		return
+	}
+
+	// Load the strategy selection and its parameters from the YAML config file.
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		fmt.Printf("Error loading config: %v\n", err)
+		return
+	}
+
+	// The path to the directory containing the CSV files, relative to the config file.
+	dataDir := filepath.Join("..", cfg.DataDir)
 
 	// Find all CSV files in the specified directory.
 	fmt.Printf("Searching for CSV files in %s...\n", dataDir)
@@ -205,11 +359,12 @@ func main() {
 	fmt.Printf("Found %d CSV files.\n", len(files))
 	fmt.Println("----------------------------------------")
 
-	// Store results to present a final summary and a complete ledger
-	results := make(map[string]float64)
-	var allTrades []Trade
+	// Build one tickerState per file: its own strategy instance and profit
+	// tracker, indexed by date so every ticker can be advanced in lock-step.
+	states := make(map[string]*tickerState)
+	var allDates []string
+	seenDates := make(map[string]bool)
 
-	// Loop through each file and run the backtest.
 	for _, filePath := range files {
 		// Extract the ticker from the filename
 		baseName := filepath.Base(filePath)
@@ -221,32 +376,107 @@ func main() {
 			fmt.Printf("Error reading data for %s: %v\n", ticker, err)
 			continue
 		}
-
 		fmt.Printf("Successfully read %d days of data for %s.\n", df.Nrow(), ticker)
-		
-		trades := backtestStrategy(df, ticker)
-
-		if len(trades) > 0 {
-			var totalProfitLoss float64
-			var winningTrades int
-			for _, trade := range trades {
-				totalProfitLoss += trade.ProfitLossAbs
-				if trade.ProfitLossAbs > 0 {
-					winningTrades++
-				}
-			}
 
-			fmt.Printf("\n--- Backtest Summary for %s ---\n", ticker)
-			fmt.Printf("Total Trades: %d\n", len(trades))
-			fmt.Printf("Total P/L: $%.2f\n", totalProfitLoss)
-			fmt.Printf("Win Rate: %.2f%%\n", float64(winningTrades)/float64(len(trades))*100)
-			fmt.Println("----------------------------------------")
-			results[ticker] = totalProfitLoss
-			allTrades = append(allTrades, trades...)
+		dividends, err := loadDividends(dataDir, ticker)
+		if err != nil {
+			fmt.Printf("Error loading dividends for %s: %v\n", ticker, err)
+			continue
+		}
+
+		var strat strategies.Strategy
+		if cfg.Strategy == "dividend_capture" {
+			exDates := make(map[string]bool, len(dividends))
+			for date := range dividends {
+				exDates[date] = true
+			}
+			strat, err = strategies.NewDividendCapture(exDates, cfg.Params.DividendCaptureDaysBefore, cfg.Params.DividendCaptureDaysAfter)
+			if err != nil {
+				fmt.Printf("Error building strategy for %s: %v\n", ticker, err)
+				continue
+			}
 		} else {
+			strat, err = strategies.New(cfg.Strategy, cfg.Params)
+			if err != nil {
+				fmt.Printf("Error building strategy for %s: %v\n", ticker, err)
+				continue
+			}
+		}
+
+		tracker := report.NewProfitTracker(report.Config{
+			ShortMALength:         cfg.Params.ShortWindow,
+			LongMALength:          cfg.Params.LongWindow,
+			UnitSize:              cfg.Portfolio.UnitSize,
+			InitialCapital:        initialCapital,
+			ProfitMAWindow:        profitMAWindow,
+			ShortTermProfitWindow: shortTermProfitWindow,
+		})
+
+		ts, err := newTickerState(ticker, df, strat, tracker, cfg.Params.ATRWindow, dividends)
+		if err != nil {
+			fmt.Printf("Error preparing backtest for %s: %v\n", ticker, err)
+			continue
+		}
+		states[ticker] = ts
+
+		for date := range ts.dateToIndex {
+			if !seenDates[date] {
+				seenDates[date] = true
+				allDates = append(allDates, date)
+			}
+		}
+	}
+	sort.Strings(allDates)
+
+	// Run every ticker's strategy in lock-step across the whole portfolio.
+	pf := portfolio.New(initialCapital, cfg.Portfolio)
+	var allTrades []Trade
+	if len(allDates) > 0 {
+		allTrades = runPortfolioBacktest(states, allDates, pf)
+	}
+
+	if err := pf.WriteEquityCurveCSV("./generated_data/equity_curve.csv"); err != nil {
+		fmt.Printf("Error writing equity curve: %v\n", err)
+	}
+
+	// Write each ticker's profit report and per-ticker trade summary.
+	results := make(map[string]float64)
+	tradesByTicker := make(map[string][]Trade)
+	for _, trade := range allTrades {
+		tradesByTicker[trade.Ticker] = append(tradesByTicker[trade.Ticker], trade)
+	}
+
+	for ticker, ts := range states {
+		profitReportPath := fmt.Sprintf("./generated_data/%s_profit_report.tsv", ticker)
+		if err := ts.Tracker.WriteTSV(profitReportPath); err != nil {
+			fmt.Printf("Error writing profit report for %s: %v\n", ticker, err)
+		}
+
+		trades := tradesByTicker[ticker]
+		if len(trades) == 0 {
 			fmt.Printf("No trades were executed with this strategy for %s.\n", ticker)
 			fmt.Println("-----------------")
+			continue
 		}
+
+		var totalProfitLoss float64
+		var totalDividends float64
+		var winningTrades int
+		for _, trade := range trades {
+			totalProfitLoss += trade.ProfitLossAbs
+			totalDividends += trade.DividendsCollected
+			if trade.ProfitLossAbs > 0 {
+				winningTrades++
+			}
+		}
+
+		fmt.Printf("\n--- Backtest Summary for %s ---\n", ticker)
+		fmt.Printf("Total Trades: %d\n", len(trades))
+		fmt.Printf("Total P/L: $%.2f (incl. $%.2f in dividends)\n", totalProfitLoss+totalDividends, totalDividends)
+		fmt.Printf("Win Rate: %.2f%%\n", float64(winningTrades)/float64(len(trades))*100)
+		printTradeStats(stats.ComputeTradeStats(toStatsTrades(trades), initialCapital))
+		fmt.Println("----------------------------------------")
+		results[ticker] = totalProfitLoss + totalDividends
 	}
 
 	// Final summary of all backtested stocks
@@ -272,6 +502,13 @@ func main() {
 		}
 	}
 
+	// Portfolio-wide trade statistics across every ticker that was backtested.
+	if len(allTrades) > 0 {
+		fmt.Println("\n--- Portfolio-Wide Trade Statistics ---")
+		printTradeStats(stats.ComputeTradeStats(toStatsTrades(allTrades), initialCapital))
+		fmt.Println("----------------------------------------")
+	}
+
 	// Print a detailed ledger of all trades to a file
 	if len(allTrades) > 0 {
 		fmt.Println("\n\n--- Writing Trade Ledger to File ---")
@@ -291,12 +528,12 @@ func main() {
 		})
 		
 		// Write the header with portfolio value.
-		fmt.Fprintf(writer, "%-10s | %-12s | %-12s | %-12s | %-12s | %-12s | %-15s\n", "Ticker", "Entry Date", "Exit Date", "Entry Price", "Exit Price", "P/L %", "P/L ($)")
+		fmt.Fprintf(writer, "%-10s | %-12s | %-12s | %-12s | %-12s | %-12s | %-15s | %-12s\n", "Ticker", "Entry Date", "Exit Date", "Entry Price", "Exit Price", "P/L %", "P/L ($)", "Dividends ($)")
 		fmt.Fprintln(writer, strings.Repeat("-", 100))
 
 		// Write each trade to the file.
 		for _, trade := range allTrades {
-			fmt.Fprintf(writer, "%-10s | %-12s | %-12s | %-12.2f | %-12.2f | %-12.2f%% | %-15.2f\n", 
+			fmt.Fprintf(writer, "%-10s | %-12s | %-12s | %-12.2f | %-12.2f | %-12.2f%% | %-15.2f | %-12.2f\n",
 				trade.Ticker,
 				trade.EntryDate,
 				trade.ExitDate,
@@ -304,6 +541,7 @@ func main() {
 				trade.ExitPrice,
 				trade.ProfitLoss*100,
 				trade.ProfitLossAbs,
+				trade.DividendsCollected,
 			)
 		}
 		
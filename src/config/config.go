@@ -0,0 +1,37 @@
+// Package config loads the YAML file that selects a strategy and its
+// parameters, so the backtester can be re-parameterized without recompiling.
+package config
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+
+	"managed_etf/src/optimize"
+	"managed_etf/src/portfolio"
+	"managed_etf/src/strategies"
+)
+
+// Config is the top-level shape of the backtester's YAML config file.
+type Config struct {
+	DataDir   string                 `yaml:"data_dir"`
+	Strategy  string                 `yaml:"strategy"`
+	Params    strategies.Params      `yaml:"params"`
+	Portfolio portfolio.SizingConfig `yaml:"portfolio"`
+	Optimize  optimize.Config        `yaml:"optimize"`
+}
+
+// Load reads and parses the YAML config file at path.
+func Load(path string) (Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Config{}, fmt.Errorf("could not read config file: %w", err)
+	}
+
+	var cfg Config
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return Config{}, fmt.Errorf("could not parse config file: %w", err)
+	}
+	return cfg, nil
+}
@@ -0,0 +1,327 @@
+// Package optimize implements rolling walk-forward parameter optimization:
+// for each train/test window it grid-searches the moving-average windows
+// that maximize a chosen objective in-sample, then measures that choice
+// out-of-sample, so reported performance reflects honest generalization
+// rather than a single overfit in-sample run.
+package optimize
+
+import (
+	"fmt"
+	"math"
+	"runtime"
+	"sync"
+
+	"github.com/go-gota/gota/dataframe"
+
+	"managed_etf/src/portfolio"
+	"managed_etf/src/stats"
+	"managed_etf/src/strategies"
+)
+
+// Objective selects which trade-statistics metric the grid search maximizes.
+type Objective string
+
+const (
+	Sharpe      Objective = "sharpe"
+	Calmar      Objective = "calmar"
+	TotalReturn Objective = "total_return"
+)
+
+// Config controls the (ShortWindow, LongWindow) sweep and the train/test
+// window geometry of the walk-forward run.
+type Config struct {
+	ShortWindowMin  int `yaml:"short_window_min"`
+	ShortWindowMax  int `yaml:"short_window_max"`
+	ShortWindowStep int `yaml:"short_window_step"`
+	LongWindowMin   int `yaml:"long_window_min"`
+	LongWindowMax   int `yaml:"long_window_max"`
+	LongWindowStep  int `yaml:"long_window_step"`
+
+	TrainBars int `yaml:"train_bars"` // bars in each in-sample training window
+	TestBars  int `yaml:"test_bars"`  // bars in each out-of-sample test window
+	StepBars  int `yaml:"step_bars"`  // bars to advance the window between steps
+
+	Objective      Objective `yaml:"objective"`
+	InitialCapital float64   `yaml:"initial_capital"`
+}
+
+// Candidate is one point in the (ShortWindow, LongWindow) parameter grid.
+type Candidate struct {
+	ShortWindow int
+	LongWindow  int
+}
+
+// grid returns every Candidate in cfg's sweep ranges, skipping pairs where
+// the long window isn't actually longer than the short one.
+func grid(cfg Config) []Candidate {
+	var candidates []Candidate
+	for s := cfg.ShortWindowMin; s <= cfg.ShortWindowMax; s += cfg.ShortWindowStep {
+		for l := cfg.LongWindowMin; l <= cfg.LongWindowMax; l += cfg.LongWindowStep {
+			if l <= s {
+				continue
+			}
+			candidates = append(candidates, Candidate{ShortWindow: s, LongWindow: l})
+		}
+	}
+	return candidates
+}
+
+// WindowResult is one train->test step of the walk-forward sweep.
+type WindowResult struct {
+	TrainStart, TrainEnd int // bar indices, end-exclusive
+	TestStart, TestEnd   int
+
+	BestParams  Candidate
+	InSample    stats.TradeStats
+	OutOfSample stats.TradeStats
+	OOSTrades   []stats.Trade
+}
+
+// Report is the outcome of walk-forward optimizing one ticker's price
+// series: every window's chosen parameters and in/out-of-sample stats, plus
+// the combined statistics over every window's out-of-sample trades stitched
+// together in order.
+type Report struct {
+	Ticker   string
+	Windows  []WindowResult
+	Combined stats.TradeStats
+}
+
+// validate rejects a Config whose grid or window geometry would never
+// advance: a non-positive step makes grid's and Run's loops spin forever,
+// and a non-positive window size or train/test range makes the search space
+// ill-defined rather than merely empty.
+func validate(cfg Config) error {
+	if cfg.ShortWindowStep <= 0 {
+		return fmt.Errorf("short_window_step must be positive, got %d", cfg.ShortWindowStep)
+	}
+	if cfg.LongWindowStep <= 0 {
+		return fmt.Errorf("long_window_step must be positive, got %d", cfg.LongWindowStep)
+	}
+	if cfg.ShortWindowMin > cfg.ShortWindowMax {
+		return fmt.Errorf("short_window_min (%d) must not exceed short_window_max (%d)", cfg.ShortWindowMin, cfg.ShortWindowMax)
+	}
+	if cfg.LongWindowMin > cfg.LongWindowMax {
+		return fmt.Errorf("long_window_min (%d) must not exceed long_window_max (%d)", cfg.LongWindowMin, cfg.LongWindowMax)
+	}
+	if cfg.TrainBars <= 0 {
+		return fmt.Errorf("train_bars must be positive, got %d", cfg.TrainBars)
+	}
+	if cfg.TestBars <= 0 {
+		return fmt.Errorf("test_bars must be positive, got %d", cfg.TestBars)
+	}
+	if cfg.StepBars <= 0 {
+		return fmt.Errorf("step_bars must be positive, got %d", cfg.StepBars)
+	}
+	return nil
+}
+
+// Run performs rolling walk-forward optimization over df: for every
+// TrainBars/TestBars window (advancing StepBars between steps) it
+// grid-searches the in-sample period for cfg.Objective, evaluates the
+// winning parameters out-of-sample, and combines every window's
+// out-of-sample trades into one set of statistics.
+func Run(ticker string, df dataframe.DataFrame, strategyName string, baseParams strategies.Params, cfg Config) (Report, error) {
+	if strategyName == "dividend_capture" {
+		return Report{}, fmt.Errorf("dividend_capture has no short/long moving-average windows to walk-forward optimize")
+	}
+
+	if err := validate(cfg); err != nil {
+		return Report{}, fmt.Errorf("invalid optimize config: %w", err)
+	}
+
+	candidates := grid(cfg)
+	if len(candidates) == 0 {
+		return Report{}, fmt.Errorf("parameter grid is empty: check short/long window ranges")
+	}
+
+	report := Report{Ticker: ticker}
+	var combinedTrades []stats.Trade
+
+	n := df.Nrow()
+	for trainStart := 0; trainStart+cfg.TrainBars+cfg.TestBars <= n; trainStart += cfg.StepBars {
+		trainEnd := trainStart + cfg.TrainBars
+		testEnd := trainEnd + cfg.TestBars
+
+		best, inSample, err := bestCandidate(df, strategyName, baseParams, candidates, trainStart, trainEnd, cfg)
+		if err != nil {
+			return report, fmt.Errorf("window starting at bar %d: %w", trainStart, err)
+		}
+
+		oosTrades, err := runWindow(df, strategyName, baseParams, best, trainEnd, testEnd, cfg.InitialCapital)
+		if err != nil {
+			return report, fmt.Errorf("window starting at bar %d: %w", trainStart, err)
+		}
+
+		report.Windows = append(report.Windows, WindowResult{
+			TrainStart:  trainStart,
+			TrainEnd:    trainEnd,
+			TestStart:   trainEnd,
+			TestEnd:     testEnd,
+			BestParams:  best,
+			InSample:    inSample,
+			OutOfSample: stats.ComputeTradeStats(oosTrades, cfg.InitialCapital),
+			OOSTrades:   oosTrades,
+		})
+		combinedTrades = append(combinedTrades, oosTrades...)
+	}
+
+	if len(report.Windows) == 0 {
+		return report, fmt.Errorf("series has %d bars, too short for train=%d/test=%d bar windows", n, cfg.TrainBars, cfg.TestBars)
+	}
+
+	report.Combined = stats.ComputeTradeStats(combinedTrades, cfg.InitialCapital)
+	return report, nil
+}
+
+// bestCandidate grid-searches candidates over df's window [start, end) in
+// parallel across a runtime.NumCPU() worker pool, since each candidate's
+// backtest is independent, and returns whichever maximizes cfg.Objective.
+// Results are reduced in candidates order, not worker-arrival order, so a
+// tie always resolves to the earlier candidate regardless of which worker
+// finishes first.
+func bestCandidate(df dataframe.DataFrame, strategyName string, baseParams strategies.Params, candidates []Candidate, start, end int, cfg Config) (Candidate, stats.TradeStats, error) {
+	type job struct {
+		index     int
+		candidate Candidate
+	}
+	type result struct {
+		index int
+		ts    stats.TradeStats
+		score float64
+		err   error
+	}
+
+	jobs := make(chan job)
+	results := make(chan result)
+
+	workers := runtime.NumCPU()
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				trades, err := runWindow(df, strategyName, baseParams, j.candidate, start, end, cfg.InitialCapital)
+				if err != nil {
+					results <- result{index: j.index, err: err}
+					continue
+				}
+				ts := stats.ComputeTradeStats(trades, cfg.InitialCapital)
+				results <- result{index: j.index, ts: ts, score: objectiveScore(ts, trades, cfg.InitialCapital, cfg.Objective)}
+			}
+		}()
+	}
+
+	go func() {
+		for i, candidate := range candidates {
+			jobs <- job{index: i, candidate: candidate}
+		}
+		close(jobs)
+	}()
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	resultsByIndex := make([]result, len(candidates))
+	seen := make([]bool, len(candidates))
+	for res := range results {
+		resultsByIndex[res.index] = res
+		seen[res.index] = true
+	}
+
+	found := false
+	bestScore := math.Inf(-1)
+	var best Candidate
+	var bestStats stats.TradeStats
+	for i, res := range resultsByIndex {
+		if !seen[i] || res.err != nil {
+			continue
+		}
+		if !found || res.score > bestScore {
+			found = true
+			bestScore = res.score
+			best = candidates[i]
+			bestStats = res.ts
+		}
+	}
+	if !found {
+		return Candidate{}, stats.TradeStats{}, fmt.Errorf("no candidate produced a valid backtest")
+	}
+	return best, bestStats, nil
+}
+
+// objectiveScore extracts the metric cfg.Objective selects from ts, falling
+// back to computing total return directly from trades since TradeStats
+// doesn't carry a plain cumulative-return field.
+func objectiveScore(ts stats.TradeStats, trades []stats.Trade, initialCapital float64, objective Objective) float64 {
+	switch objective {
+	case Calmar:
+		return ts.Calmar
+	case TotalReturn:
+		var total float64
+		for _, trade := range trades {
+			total += trade.ProfitLossAbs
+		}
+		return total / initialCapital
+	default:
+		return ts.SharpeRatio
+	}
+}
+
+// runWindow backtests strategyName over df's bars [start, end), with
+// candidate's MA windows substituted into baseParams, starting a fresh
+// single-ticker portfolio so windows never leak equity into one another.
+func runWindow(df dataframe.DataFrame, strategyName string, baseParams strategies.Params, candidate Candidate, start, end int, initialCapital float64) ([]stats.Trade, error) {
+	params := baseParams
+	params.ShortWindow = candidate.ShortWindow
+	params.LongWindow = candidate.LongWindow
+
+	strat, err := strategies.New(strategyName, params)
+	if err != nil {
+		return nil, fmt.Errorf("could not build strategy: %w", err)
+	}
+	if err := strat.Init(df); err != nil {
+		return nil, fmt.Errorf("could not initialize strategy: %w", err)
+	}
+
+	const ticker = "window"
+	dateSeries := df.Col("DATE")
+	openSeries := df.Col("OPEN")
+	pf := portfolio.New(initialCapital, portfolio.SizingConfig{Mode: portfolio.FixedUnit, UnitSize: initialCapital})
+
+	warmup := strat.WarmupBars()
+	var trades []stats.Trade
+	for i := start; i < end; i++ {
+		if i < warmup {
+			continue
+		}
+		date := dateSeries.Elem(i).String()
+		switch strat.OnBar(i) {
+		case strategies.Buy:
+			pf.Buy(ticker, date, openSeries.Elem(i).Float(), 0)
+		case strategies.Sell:
+			if closed, ok := pf.Sell(ticker, date, openSeries.Elem(i).Float()); ok {
+				trades = append(trades, toStatsTrade(closed))
+			}
+		}
+	}
+	if end > start {
+		if closed, ok := pf.Sell(ticker, dateSeries.Elem(end-1).String(), openSeries.Elem(end-1).Float()); ok {
+			trades = append(trades, toStatsTrade(closed))
+		}
+	}
+	return trades, nil
+}
+
+// toStatsTrade converts a closed portfolio trade into the minimal shape the
+// stats package needs to compute its metrics.
+func toStatsTrade(closed portfolio.ClosedTrade) stats.Trade {
+	return stats.Trade{
+		EntryDate:     closed.EntryDate,
+		ExitDate:      closed.ExitDate,
+		ProfitLoss:    closed.ProfitLoss,
+		ProfitLossAbs: closed.ProfitLossAbs,
+	}
+}
@@ -0,0 +1,225 @@
+// Package portfolio owns cash and open positions across every ticker in a
+// backtest run, so position sizing and drawdown can be computed against real
+// account equity instead of treating each trade as an isolated, fixed-size unit.
+package portfolio
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+)
+
+// SizingMode selects how Buy turns a fill price into a share count.
+type SizingMode string
+
+const (
+	// FixedUnit spends a fixed dollar amount per trade, regardless of equity.
+	FixedUnit SizingMode = "fixed_unit"
+	// PercentEquity spends a fixed fraction of current total equity per trade.
+	PercentEquity SizingMode = "percent_equity"
+	// VolatilityTargeted sizes the trade so that RiskPerTrade of equity is put
+	// at risk for a one-ATR adverse move, i.e. shares = riskPerTrade*equity / (ATR*priceMultiple).
+	VolatilityTargeted SizingMode = "volatility_targeted"
+)
+
+// SizingConfig controls how Portfolio.Buy sizes new positions.
+type SizingConfig struct {
+	Mode          SizingMode `yaml:"sizing_mode"`
+	UnitSize      float64    `yaml:"unit_size"`      // dollar amount per trade, for FixedUnit
+	PercentEquity float64    `yaml:"percent_equity"` // fraction of equity per trade, for PercentEquity
+	RiskPerTrade  float64    `yaml:"risk_per_trade"` // fraction of equity risked per trade, for VolatilityTargeted
+	PriceMultiple float64    `yaml:"price_multiple"` // dollar value of a one-unit price move, for VolatilityTargeted
+}
+
+// Position is a currently open holding in a single ticker.
+type Position struct {
+	Ticker             string
+	Shares             float64
+	EntryPrice         float64
+	EntryDate          string
+	DividendsCollected float64 // dividends credited while this position has been open
+}
+
+// ClosedTrade is the outcome of a Sell, in the minimal shape callers need to
+// build their own trade-ledger record.
+type ClosedTrade struct {
+	Ticker             string
+	EntryDate          string
+	ExitDate           string
+	EntryPrice         float64
+	ExitPrice          float64
+	ProfitLoss         float64 // percentage return
+	ProfitLossAbs      float64 // absolute dollar P/L
+	DividendsCollected float64 // dividends credited while the position was open
+}
+
+// EquitySnapshot is one row of the daily equity curve.
+type EquitySnapshot struct {
+	Date           string
+	Cash           float64
+	PositionsValue float64
+	TotalEquity    float64
+	DrawdownPct    float64
+}
+
+// Portfolio tracks cash and open positions across every ticker in a backtest
+// run and records a daily equity snapshot as MarkToMarket is called.
+type Portfolio struct {
+	Cash      float64
+	Sizing    SizingConfig
+	Positions map[string]Position
+
+	lastTotalEquity float64
+	peakEquity      float64
+	equityCurve     []EquitySnapshot
+}
+
+// New returns a Portfolio starting with initialCash, sized according to sizing.
+func New(initialCash float64, sizing SizingConfig) *Portfolio {
+	return &Portfolio{
+		Cash:            initialCash,
+		Sizing:          sizing,
+		Positions:       make(map[string]Position),
+		lastTotalEquity: initialCash,
+		peakEquity:      initialCash,
+	}
+}
+
+// positionSize returns the number of shares a new trade at price should
+// open, given the most recently marked-to-market equity and (if relevant to
+// the sizing mode) the instrument's current ATR.
+func (p *Portfolio) positionSize(price, atrValue float64) float64 {
+	switch p.Sizing.Mode {
+	case PercentEquity:
+		return (p.Sizing.PercentEquity * p.lastTotalEquity) / price
+	case VolatilityTargeted:
+		if atrValue <= 0 {
+			// No usable volatility estimate; fall back to a plain dollar unit
+			// rather than sizing a trade off a meaningless ATR of zero.
+			return p.Sizing.UnitSize / price
+		}
+		priceMultiple := p.Sizing.PriceMultiple
+		if priceMultiple == 0 {
+			priceMultiple = 1
+		}
+		return (p.Sizing.RiskPerTrade * p.lastTotalEquity) / (atrValue * priceMultiple)
+	default:
+		return p.Sizing.UnitSize / price
+	}
+}
+
+// Buy opens a position in ticker at price if one isn't already open and cash
+// covers the sized cost. atrValue is only consulted in VolatilityTargeted
+// sizing mode and may be passed as zero otherwise. It reports whether the
+// trade was taken.
+func (p *Portfolio) Buy(ticker, date string, price, atrValue float64) bool {
+	if _, open := p.Positions[ticker]; open {
+		return false
+	}
+	if price <= 0 {
+		return false
+	}
+
+	shares := p.positionSize(price, atrValue)
+	cost := shares * price
+	if shares <= 0 || cost > p.Cash {
+		return false
+	}
+
+	p.Cash -= cost
+	p.Positions[ticker] = Position{Ticker: ticker, Shares: shares, EntryPrice: price, EntryDate: date}
+	return true
+}
+
+// Sell closes the open position in ticker at price, crediting the proceeds
+// to cash and returning the closed trade. It reports false if no position is open.
+func (p *Portfolio) Sell(ticker, date string, price float64) (ClosedTrade, bool) {
+	pos, open := p.Positions[ticker]
+	if !open {
+		return ClosedTrade{}, false
+	}
+	delete(p.Positions, ticker)
+
+	p.Cash += pos.Shares * price
+
+	return ClosedTrade{
+		Ticker:             ticker,
+		EntryDate:          pos.EntryDate,
+		ExitDate:           date,
+		EntryPrice:         pos.EntryPrice,
+		ExitPrice:          price,
+		ProfitLoss:         (price - pos.EntryPrice) / pos.EntryPrice,
+		ProfitLossAbs:      (price - pos.EntryPrice) * pos.Shares,
+		DividendsCollected: pos.DividendsCollected,
+	}, true
+}
+
+// CreditDividend credits amountPerShare * shares to ticker's open position:
+// the cash is added immediately, and the amount is accumulated on the
+// position so it is reported on the trade's DividendsCollected field once
+// the position closes. It reports false if no position is open.
+func (p *Portfolio) CreditDividend(ticker string, amountPerShare float64) bool {
+	pos, open := p.Positions[ticker]
+	if !open {
+		return false
+	}
+	dividend := pos.Shares * amountPerShare
+	p.Cash += dividend
+	pos.DividendsCollected += dividend
+	p.Positions[ticker] = pos
+	return true
+}
+
+// MarkToMarket values every open position at the price given in prices
+// (missing tickers fall back to their entry price) and records the
+// resulting cash/positions/equity/drawdown snapshot for date.
+func (p *Portfolio) MarkToMarket(date string, prices map[string]float64) {
+	var positionsValue float64
+	for ticker, pos := range p.Positions {
+		price, ok := prices[ticker]
+		if !ok {
+			price = pos.EntryPrice
+		}
+		positionsValue += pos.Shares * price
+	}
+
+	totalEquity := p.Cash + positionsValue
+	if totalEquity > p.peakEquity {
+		p.peakEquity = totalEquity
+	}
+	var drawdownPct float64
+	if p.peakEquity > 0 {
+		drawdownPct = (p.peakEquity - totalEquity) / p.peakEquity
+	}
+
+	p.lastTotalEquity = totalEquity
+	p.equityCurve = append(p.equityCurve, EquitySnapshot{
+		Date:           date,
+		Cash:           p.Cash,
+		PositionsValue: positionsValue,
+		TotalEquity:    totalEquity,
+		DrawdownPct:    drawdownPct,
+	})
+}
+
+// WriteEquityCurveCSV writes the recorded daily equity snapshots to path as
+// date,cash,positions_value,total_equity,drawdown_pct.
+func (p *Portfolio) WriteEquityCurveCSV(path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("could not create equity curve file: %w", err)
+	}
+	defer file.Close()
+
+	writer := bufio.NewWriter(file)
+
+	fmt.Fprintln(writer, "date,cash,positions_value,total_equity,drawdown_pct")
+	for _, snap := range p.equityCurve {
+		fmt.Fprintf(writer, "%s,%.2f,%.2f,%.2f,%.4f\n", snap.Date, snap.Cash, snap.PositionsValue, snap.TotalEquity, snap.DrawdownPct)
+	}
+
+	if err := writer.Flush(); err != nil {
+		return fmt.Errorf("could not flush equity curve file: %w", err)
+	}
+	return nil
+}
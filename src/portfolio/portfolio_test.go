@@ -0,0 +1,84 @@
+package portfolio
+
+import "testing"
+
+func TestBuyAndSellFixedUnit(t *testing.T) {
+	p := New(1000, SizingConfig{Mode: FixedUnit, UnitSize: 500})
+
+	if ok := p.Buy("AAPL", "2022-01-03", 50, 0); !ok {
+		t.Fatalf("Buy() = false, want true")
+	}
+	if got, want := p.Cash, 500.0; got != want {
+		t.Errorf("Cash after buy = %.2f, want %.2f", got, want)
+	}
+
+	// A second Buy while the position is still open must be rejected.
+	if ok := p.Buy("AAPL", "2022-01-04", 51, 0); ok {
+		t.Errorf("Buy() = true while a position is already open, want false")
+	}
+
+	closed, ok := p.Sell("AAPL", "2022-01-10", 60)
+	if !ok {
+		t.Fatalf("Sell() = false, want true")
+	}
+	if got, want := closed.ProfitLossAbs, 100.0; got != want {
+		t.Errorf("ProfitLossAbs = %.2f, want %.2f", got, want)
+	}
+	if got, want := p.Cash, 1100.0; got != want {
+		t.Errorf("Cash after sell = %.2f, want %.2f", got, want)
+	}
+
+	// Selling again with nothing open must report false, not panic.
+	if _, ok := p.Sell("AAPL", "2022-01-11", 60); ok {
+		t.Errorf("Sell() = true with no open position, want false")
+	}
+}
+
+func TestBuyRejectsWhenCashInsufficient(t *testing.T) {
+	p := New(100, SizingConfig{Mode: FixedUnit, UnitSize: 500})
+	if ok := p.Buy("AAPL", "2022-01-03", 50, 0); ok {
+		t.Errorf("Buy() = true with insufficient cash, want false")
+	}
+}
+
+func TestCreditDividendAccumulatesOnOpenPosition(t *testing.T) {
+	p := New(1000, SizingConfig{Mode: FixedUnit, UnitSize: 500})
+	p.Buy("AAPL", "2022-01-03", 50, 0)
+
+	if ok := p.CreditDividend("AAPL", 1.5); !ok {
+		t.Fatalf("CreditDividend() = false, want true")
+	}
+	wantCash := 500.0 + 10*1.5 // 500 cash left after the buy, 10 shares at $1.50/share
+	if p.Cash != wantCash {
+		t.Errorf("Cash after dividend = %.2f, want %.2f", p.Cash, wantCash)
+	}
+
+	closed, _ := p.Sell("AAPL", "2022-01-10", 50)
+	if got, want := closed.DividendsCollected, 15.0; got != want {
+		t.Errorf("DividendsCollected = %.2f, want %.2f", got, want)
+	}
+
+	// No open position: the dividend has nowhere to accrue.
+	if ok := p.CreditDividend("AAPL", 1.5); ok {
+		t.Errorf("CreditDividend() = true with no open position, want false")
+	}
+}
+
+func TestMarkToMarketTracksDrawdown(t *testing.T) {
+	p := New(1000, SizingConfig{Mode: FixedUnit, UnitSize: 500})
+	p.Buy("AAPL", "2022-01-03", 50, 0)
+
+	p.MarkToMarket("2022-01-04", map[string]float64{"AAPL": 60})
+	p.MarkToMarket("2022-01-05", map[string]float64{"AAPL": 40})
+
+	curve := p.equityCurve
+	if len(curve) != 2 {
+		t.Fatalf("len(equityCurve) = %d, want 2", len(curve))
+	}
+	if curve[0].DrawdownPct != 0 {
+		t.Errorf("DrawdownPct at new peak = %.4f, want 0", curve[0].DrawdownPct)
+	}
+	if curve[1].DrawdownPct <= 0 {
+		t.Errorf("DrawdownPct after equity fell below peak = %.4f, want > 0", curve[1].DrawdownPct)
+	}
+}
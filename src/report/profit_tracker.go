@@ -0,0 +1,143 @@
+// Package report turns a raw trade stream into per-interval statistics and
+// exports them as TSV for downstream analysis.
+package report
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+)
+
+// Config holds the parameters needed to size the rolling windows and to
+// stamp the TSV header with the strategy parameters that produced the run.
+type Config struct {
+	ShortMALength         int
+	LongMALength          int
+	UnitSize              float64
+	InitialCapital        float64
+	ProfitMAWindow        int // window (in intervals) for the accumulated-profit SMA
+	ShortTermProfitWindow int // window (in intervals) for the short-term accumulated-profit SMA
+}
+
+// interval is a single row of the report: the accumulated state of the
+// tracker as of the bar that closed this interval.
+type interval struct {
+	Date              string
+	AccumulatedProfit float64
+	AccumulatedFees   float64
+	TradeCount        int
+	WinningTrades     int
+	GrossWin          float64
+	GrossLoss         float64
+}
+
+// ProfitTracker accumulates P/L and fees across a trade stream and rotates
+// the accumulation into a new interval bucket on each call to Rotate.
+type ProfitTracker struct {
+	cfg Config
+
+	accumulatedProfit float64
+	accumulatedFees   float64
+	tradeCount        int
+	winningTrades     int
+	grossWin          float64
+	grossLoss         float64
+
+	intervals []interval
+}
+
+// NewProfitTracker returns a tracker configured with cfg. ProfitMAWindow and
+// ShortTermProfitWindow default to 60 and 14 respectively when left at zero.
+func NewProfitTracker(cfg Config) *ProfitTracker {
+	if cfg.ProfitMAWindow == 0 {
+		cfg.ProfitMAWindow = 60
+	}
+	if cfg.ShortTermProfitWindow == 0 {
+		cfg.ShortTermProfitWindow = 14
+	}
+	return &ProfitTracker{cfg: cfg}
+}
+
+// Record folds a closed trade into the current (not-yet-rotated) interval.
+func (pt *ProfitTracker) Record(profitLossAbs, fees float64) {
+	pt.accumulatedProfit += profitLossAbs
+	pt.accumulatedFees += fees
+	pt.tradeCount++
+	if profitLossAbs > 0 {
+		pt.winningTrades++
+		pt.grossWin += profitLossAbs
+	} else {
+		pt.grossLoss += profitLossAbs
+	}
+}
+
+// Rotate closes out the current interval under the given bar date and opens
+// a new one. Call it once per bar. Every rotated interval is kept and written
+// as its own TSV row; only the profit SMAs look back over a limited window.
+func (pt *ProfitTracker) Rotate(date string) {
+	pt.intervals = append(pt.intervals, interval{
+		Date:              date,
+		AccumulatedProfit: pt.accumulatedProfit,
+		AccumulatedFees:   pt.accumulatedFees,
+		TradeCount:        pt.tradeCount,
+		WinningTrades:     pt.winningTrades,
+		GrossWin:          pt.grossWin,
+		GrossLoss:         pt.grossLoss,
+	})
+}
+
+// sma returns the simple moving average of accumulated profit over the last
+// window intervals ending at index i. It returns 0 until the window fills.
+func (pt *ProfitTracker) sma(i, window int) float64 {
+	if i+1 < window {
+		return 0.0
+	}
+	var sum float64
+	for j := i - window + 1; j <= i; j++ {
+		sum += pt.intervals[j].AccumulatedProfit
+	}
+	return sum / float64(window)
+}
+
+// WriteTSV writes one row per interval to path, preceded by a header row
+// that records strategy parameters so a report can be identified without
+// cross-referencing the run that produced it.
+func (pt *ProfitTracker) WriteTSV(path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("could not create profit report file: %w", err)
+	}
+	defer file.Close()
+
+	writer := bufio.NewWriter(file)
+
+	fmt.Fprintf(writer, "# ShortMALength=%d LongMALength=%d UnitSize=%.2f InitialCapital=%.2f ProfitMAWindow=%d ShortTermProfitWindow=%d\n",
+		pt.cfg.ShortMALength, pt.cfg.LongMALength, pt.cfg.UnitSize, pt.cfg.InitialCapital, pt.cfg.ProfitMAWindow, pt.cfg.ShortTermProfitWindow)
+	fmt.Fprintln(writer, "Date\tAccumulatedProfit\tAccumulatedFees\tTradeCount\tWinRatio\tProfitFactor\tProfitMA\tShortTermProfitMA")
+
+	for i, iv := range pt.intervals {
+		winRatio := 0.0
+		if iv.TradeCount > 0 {
+			winRatio = float64(iv.WinningTrades) / float64(iv.TradeCount)
+		}
+		profitFactor := 0.0
+		if iv.GrossLoss != 0 {
+			profitFactor = iv.GrossWin / -iv.GrossLoss
+		}
+		fmt.Fprintf(writer, "%s\t%.2f\t%.2f\t%d\t%.4f\t%.4f\t%.2f\t%.2f\n",
+			iv.Date,
+			iv.AccumulatedProfit,
+			iv.AccumulatedFees,
+			iv.TradeCount,
+			winRatio,
+			profitFactor,
+			pt.sma(i, pt.cfg.ProfitMAWindow),
+			pt.sma(i, pt.cfg.ShortTermProfitWindow),
+		)
+	}
+
+	if err := writer.Flush(); err != nil {
+		return fmt.Errorf("could not flush profit report file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,212 @@
+// Package stats turns a closed-trade stream into the risk/return metrics a
+// real backtesting harness is expected to report, rather than a bare P/L tally.
+package stats
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// tradeDateLayout matches the date format emitted by the Yahoo Finance CSVs
+// this tool backtests against.
+const tradeDateLayout = "2006-01-02"
+
+// tradingDaysPerYear approximates the number of trading days in a year, used
+// to annualize the per-trade Sharpe and Sortino ratios.
+const tradingDaysPerYear = 252.0
+
+// Trade is the subset of trade data stats needs to compute its metrics.
+type Trade struct {
+	EntryDate     string
+	ExitDate      string
+	ProfitLoss    float64 // percentage return for the trade
+	ProfitLossAbs float64 // absolute dollar P/L for the trade
+}
+
+// TradeStats summarizes a stream of closed trades.
+type TradeStats struct {
+	TotalTrades          int
+	WinningTrades        int
+	LosingTrades         int
+	WinRate              float64
+	GrossProfit          float64
+	GrossLoss            float64 // negative or zero
+	ProfitFactor         float64
+	AverageWin           float64
+	AverageLoss          float64 // positive magnitude
+	Expectancy           float64
+	MaxConsecutiveWins   int
+	MaxConsecutiveLosses int
+	SharpeRatio          float64
+	SortinoRatio         float64
+	MaxDrawdown          float64 // as a fraction of the peak, e.g. 0.2 = 20%
+	MaxDrawdownDuration  int     // number of trades from peak to trough
+	CAGR                 float64
+	Calmar               float64
+}
+
+// ComputeTradeStats computes the full trade-statistics summary for trades,
+// assuming the account starts at initialCapital and every trade's absolute
+// P/L is added to a single running equity curve in entry-date order.
+func ComputeTradeStats(trades []Trade, initialCapital float64) TradeStats {
+	var stats TradeStats
+	if len(trades) == 0 {
+		return stats
+	}
+
+	sorted := make([]Trade, len(trades))
+	copy(sorted, trades)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].EntryDate < sorted[j].EntryDate
+	})
+
+	stats.TotalTrades = len(sorted)
+
+	var consecutiveWins, consecutiveLosses int
+	equity := initialCapital
+	peak := equity
+	peakIndex := 0
+	var maxDrawdown float64
+	var maxDrawdownDuration int
+
+	returns := make([]float64, len(sorted))
+
+	for i, trade := range sorted {
+		returns[i] = trade.ProfitLoss
+
+		if trade.ProfitLossAbs > 0 {
+			stats.WinningTrades++
+			stats.GrossProfit += trade.ProfitLossAbs
+			consecutiveWins++
+			consecutiveLosses = 0
+		} else {
+			stats.LosingTrades++
+			stats.GrossLoss += trade.ProfitLossAbs
+			consecutiveLosses++
+			consecutiveWins = 0
+		}
+		if consecutiveWins > stats.MaxConsecutiveWins {
+			stats.MaxConsecutiveWins = consecutiveWins
+		}
+		if consecutiveLosses > stats.MaxConsecutiveLosses {
+			stats.MaxConsecutiveLosses = consecutiveLosses
+		}
+
+		equity += trade.ProfitLossAbs
+		if equity > peak {
+			peak = equity
+			peakIndex = i + 1
+		} else if peak > 0 {
+			drawdown := (peak - equity) / peak
+			if drawdown > maxDrawdown {
+				maxDrawdown = drawdown
+				maxDrawdownDuration = (i + 1) - peakIndex
+			}
+		}
+	}
+
+	stats.WinRate = float64(stats.WinningTrades) / float64(stats.TotalTrades)
+	if stats.WinningTrades > 0 {
+		stats.AverageWin = stats.GrossProfit / float64(stats.WinningTrades)
+	}
+	if stats.LosingTrades > 0 {
+		stats.AverageLoss = -stats.GrossLoss / float64(stats.LosingTrades)
+	}
+	if stats.GrossLoss != 0 {
+		stats.ProfitFactor = stats.GrossProfit / -stats.GrossLoss
+	}
+	lossRate := 1 - stats.WinRate
+	stats.Expectancy = stats.WinRate*stats.AverageWin - lossRate*stats.AverageLoss
+
+	stats.MaxDrawdown = maxDrawdown
+	stats.MaxDrawdownDuration = maxDrawdownDuration
+
+	mean, stddev := meanStdDev(returns)
+	downsideDev := downsideDeviation(returns)
+	periodsPerYear := annualizationFactor(sorted)
+	if stddev > 0 {
+		stats.SharpeRatio = (mean / stddev) * math.Sqrt(periodsPerYear)
+	}
+	if downsideDev > 0 {
+		stats.SortinoRatio = (mean / downsideDev) * math.Sqrt(periodsPerYear)
+	}
+
+	cagr := computeCAGR(sorted, initialCapital, equity)
+	stats.CAGR = cagr
+	if maxDrawdown > 0 {
+		stats.Calmar = cagr / maxDrawdown
+	}
+
+	return stats
+}
+
+// meanStdDev returns the sample mean and population standard deviation of values.
+func meanStdDev(values []float64) (mean, stddev float64) {
+	if len(values) == 0 {
+		return 0, 0
+	}
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	mean = sum / float64(len(values))
+
+	var sumSq float64
+	for _, v := range values {
+		diff := v - mean
+		sumSq += diff * diff
+	}
+	stddev = math.Sqrt(sumSq / float64(len(values)))
+	return mean, stddev
+}
+
+// downsideDeviation returns the root-mean-square of the negative values in
+// returns, treating non-negative returns as zero deviation. This is the
+// standard denominator for the Sortino ratio.
+func downsideDeviation(returns []float64) float64 {
+	if len(returns) == 0 {
+		return 0
+	}
+	var sumSq float64
+	for _, r := range returns {
+		if r < 0 {
+			sumSq += r * r
+		}
+	}
+	return math.Sqrt(sumSq / float64(len(returns)))
+}
+
+// annualizationFactor estimates how many trades occur per year based on the
+// span between the first entry and last exit, falling back to
+// tradingDaysPerYear if the dates can't be parsed or the trades span no time.
+func annualizationFactor(sorted []Trade) float64 {
+	first, err1 := time.Parse(tradeDateLayout, sorted[0].EntryDate)
+	last, err2 := time.Parse(tradeDateLayout, sorted[len(sorted)-1].ExitDate)
+	if err1 != nil || err2 != nil {
+		return tradingDaysPerYear
+	}
+	years := last.Sub(first).Hours() / 24 / 365.25
+	if years <= 0 {
+		return tradingDaysPerYear
+	}
+	return float64(len(sorted)) / years
+}
+
+// computeCAGR returns the compound annual growth rate of the equity curve
+// from initialCapital to finalEquity over the span of the trades.
+func computeCAGR(sorted []Trade, initialCapital, finalEquity float64) float64 {
+	if initialCapital <= 0 || finalEquity <= 0 {
+		return 0
+	}
+	first, err1 := time.Parse(tradeDateLayout, sorted[0].EntryDate)
+	last, err2 := time.Parse(tradeDateLayout, sorted[len(sorted)-1].ExitDate)
+	if err1 != nil || err2 != nil {
+		return 0
+	}
+	years := last.Sub(first).Hours() / 24 / 365.25
+	if years <= 0 {
+		return 0
+	}
+	return math.Pow(finalEquity/initialCapital, 1/years) - 1
+}
@@ -0,0 +1,86 @@
+package stats
+
+import "testing"
+
+func TestComputeTradeStats(t *testing.T) {
+	tests := []struct {
+		name           string
+		trades         []Trade
+		initialCapital float64
+
+		wantTotalTrades  int
+		wantWinningTrades int
+		wantProfitFactor float64
+		wantMaxDrawdown  float64
+	}{
+		{
+			name:           "no trades",
+			trades:         nil,
+			initialCapital: 10000,
+		},
+		{
+			name: "all winners, no drawdown",
+			trades: []Trade{
+				{EntryDate: "2022-01-03", ExitDate: "2022-01-10", ProfitLoss: 0.05, ProfitLossAbs: 100},
+				{EntryDate: "2022-01-11", ExitDate: "2022-01-18", ProfitLoss: 0.05, ProfitLossAbs: 100},
+			},
+			initialCapital:    10000,
+			wantTotalTrades:   2,
+			wantWinningTrades: 2,
+			wantMaxDrawdown:   0,
+		},
+		{
+			name: "win then loss produces a drawdown and a finite profit factor",
+			trades: []Trade{
+				{EntryDate: "2022-01-03", ExitDate: "2022-01-10", ProfitLoss: 0.10, ProfitLossAbs: 200},
+				{EntryDate: "2022-01-11", ExitDate: "2022-01-18", ProfitLoss: -0.05, ProfitLossAbs: -100},
+			},
+			initialCapital:    10000,
+			wantTotalTrades:   2,
+			wantWinningTrades: 1,
+			wantProfitFactor:  2.0,
+			wantMaxDrawdown:   100.0 / 10200.0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ComputeTradeStats(tt.trades, tt.initialCapital)
+
+			if got.TotalTrades != tt.wantTotalTrades {
+				t.Errorf("TotalTrades = %d, want %d", got.TotalTrades, tt.wantTotalTrades)
+			}
+			if got.WinningTrades != tt.wantWinningTrades {
+				t.Errorf("WinningTrades = %d, want %d", got.WinningTrades, tt.wantWinningTrades)
+			}
+			if tt.wantProfitFactor != 0 && !approxEqual(got.ProfitFactor, tt.wantProfitFactor) {
+				t.Errorf("ProfitFactor = %.4f, want %.4f", got.ProfitFactor, tt.wantProfitFactor)
+			}
+			if !approxEqual(got.MaxDrawdown, tt.wantMaxDrawdown) {
+				t.Errorf("MaxDrawdown = %.4f, want %.4f", got.MaxDrawdown, tt.wantMaxDrawdown)
+			}
+		})
+	}
+}
+
+func TestComputeTradeStatsSharpeRequiresVariance(t *testing.T) {
+	// Identical returns on every trade leave stddev at zero, which must not
+	// divide-by-zero into an infinite or NaN Sharpe ratio.
+	trades := []Trade{
+		{EntryDate: "2022-01-03", ExitDate: "2022-01-10", ProfitLoss: 0.05, ProfitLossAbs: 100},
+		{EntryDate: "2022-01-11", ExitDate: "2022-01-18", ProfitLoss: 0.05, ProfitLossAbs: 100},
+	}
+	got := ComputeTradeStats(trades, 10000)
+	if got.SharpeRatio != 0 {
+		t.Errorf("SharpeRatio = %.4f, want 0 when returns have zero variance", got.SharpeRatio)
+	}
+}
+
+func approxEqual(a, b float64) bool {
+	const epsilon = 1e-6
+	diff := a - b
+	if diff < 0 {
+		diff = -diff
+	}
+	return diff < epsilon
+}
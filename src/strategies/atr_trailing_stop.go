@@ -0,0 +1,77 @@
+package strategies
+
+import (
+	"fmt"
+
+	"github.com/go-gota/gota/dataframe"
+	"github.com/go-gota/gota/series"
+)
+
+// ATRTrailingStop wraps another Strategy and forces a Sell whenever price
+// retraces TakeProfitFactor * ATR(ATRWindow) from the peak price seen since
+// entry, regardless of what the wrapped strategy's own exit signal says.
+type ATRTrailingStop struct {
+	Inner            Strategy
+	ATRWindow        int
+	TakeProfitFactor float64
+
+	close series.Series
+	atr   series.Series
+
+	inPosition     bool
+	peakSinceEntry float64
+}
+
+// NewATRTrailingStop wraps inner with an ATR-based trailing-stop exit.
+func NewATRTrailingStop(inner Strategy, atrWindow int, takeProfitFactor float64) *ATRTrailingStop {
+	return &ATRTrailingStop{Inner: inner, ATRWindow: atrWindow, TakeProfitFactor: takeProfitFactor}
+}
+
+func (a *ATRTrailingStop) Init(df dataframe.DataFrame) error {
+	if err := a.Inner.Init(df); err != nil {
+		return err
+	}
+
+	colMap := make(map[string]bool)
+	for _, name := range df.Names() {
+		colMap[name] = true
+	}
+	if !colMap["HIGH"] || !colMap["LOW"] {
+		return fmt.Errorf("ATR trailing stop requires HIGH and LOW columns")
+	}
+
+	a.close = df.Col("CLOSE")
+	a.atr = atr(df.Col("HIGH"), df.Col("LOW"), a.close, a.ATRWindow)
+	return nil
+}
+
+func (a *ATRTrailingStop) WarmupBars() int {
+	if w := a.Inner.WarmupBars(); w > a.ATRWindow {
+		return w
+	}
+	return a.ATRWindow
+}
+
+func (a *ATRTrailingStop) OnBar(i int) Signal {
+	sig := a.Inner.OnBar(i)
+	price := a.close.Elem(i).Float()
+
+	if !a.inPosition {
+		if sig == Buy {
+			a.inPosition = true
+			a.peakSinceEntry = price
+		}
+		return sig
+	}
+
+	if price > a.peakSinceEntry {
+		a.peakSinceEntry = price
+	}
+
+	stopLevel := a.peakSinceEntry - a.TakeProfitFactor*a.atr.Elem(i).Float()
+	if price <= stopLevel || sig == Sell {
+		a.inPosition = false
+		return Sell
+	}
+	return Hold
+}
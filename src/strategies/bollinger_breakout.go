@@ -0,0 +1,40 @@
+package strategies
+
+import (
+	"fmt"
+
+	"github.com/go-gota/gota/dataframe"
+	"github.com/go-gota/gota/series"
+)
+
+// BollingerBreakout buys when price closes above the upper band and sells
+// when it closes below the lower band.
+type BollingerBreakout struct {
+	Window    int
+	NumStdDev float64
+
+	close, upper, lower series.Series
+}
+
+func (b *BollingerBreakout) Init(df dataframe.DataFrame) error {
+	if df.Nrow() < b.Window {
+		return fmt.Errorf("not enough data for Bollinger breakout: need %d rows, got %d", b.Window, df.Nrow())
+	}
+	b.close = df.Col("CLOSE")
+	_, b.upper, b.lower = bollingerBands(b.close, b.Window, b.NumStdDev)
+	return nil
+}
+
+func (b *BollingerBreakout) WarmupBars() int { return b.Window }
+
+func (b *BollingerBreakout) OnBar(i int) Signal {
+	price := b.close.Elem(i).Float()
+	switch {
+	case price > b.upper.Elem(i).Float():
+		return Buy
+	case price < b.lower.Elem(i).Float():
+		return Sell
+	default:
+		return Hold
+	}
+}
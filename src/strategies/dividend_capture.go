@@ -0,0 +1,68 @@
+package strategies
+
+import (
+	"fmt"
+
+	"github.com/go-gota/gota/dataframe"
+)
+
+// DividendCapture opens a position DaysBefore trading bars ahead of every
+// ex-dividend date in ExDividendDates and closes it DaysAfter trading bars
+// later, so users can empirically test whether the ex-date price drop really
+// cancels the dividend.
+type DividendCapture struct {
+	ExDividendDates map[string]bool
+	DaysBefore      int
+	DaysAfter       int
+
+	buyBars  map[int]bool
+	sellBars map[int]bool
+}
+
+// NewDividendCapture returns a DividendCapture strategy keyed off
+// exDividendDates, the set of ex-dividend dates loaded for this ticker.
+// Both daysBefore and daysAfter must be positive: at zero, the buy and sell
+// bars for the same ex-dividend event land on the same index, and OnBar's
+// sell-before-buy priority means the position could never actually open.
+func NewDividendCapture(exDividendDates map[string]bool, daysBefore, daysAfter int) (*DividendCapture, error) {
+	if daysBefore <= 0 {
+		return nil, fmt.Errorf("dividend_capture_days_before must be positive, got %d", daysBefore)
+	}
+	if daysAfter <= 0 {
+		return nil, fmt.Errorf("dividend_capture_days_after must be positive, got %d", daysAfter)
+	}
+	return &DividendCapture{ExDividendDates: exDividendDates, DaysBefore: daysBefore, DaysAfter: daysAfter}, nil
+}
+
+func (d *DividendCapture) Init(df dataframe.DataFrame) error {
+	dateSeries := df.Col("DATE")
+	n := dateSeries.Len()
+
+	d.buyBars = make(map[int]bool)
+	d.sellBars = make(map[int]bool)
+	for i := 0; i < n; i++ {
+		if !d.ExDividendDates[dateSeries.Elem(i).String()] {
+			continue
+		}
+		if buyBar := i - d.DaysBefore; buyBar >= 0 {
+			d.buyBars[buyBar] = true
+		}
+		if sellBar := i + d.DaysAfter; sellBar < n {
+			d.sellBars[sellBar] = true
+		}
+	}
+	return nil
+}
+
+func (d *DividendCapture) WarmupBars() int { return 0 }
+
+func (d *DividendCapture) OnBar(i int) Signal {
+	switch {
+	case d.sellBars[i]:
+		return Sell
+	case d.buyBars[i]:
+		return Buy
+	default:
+		return Hold
+	}
+}
@@ -0,0 +1,58 @@
+package strategies
+
+import (
+	"testing"
+
+	"github.com/go-gota/gota/dataframe"
+)
+
+func testDividendCaptureFrame() dataframe.DataFrame {
+	return dataframe.LoadRecords([][]string{
+		{"DATE", "OPEN", "CLOSE"},
+		{"2022-01-03", "10", "10"},
+		{"2022-01-04", "10", "10"},
+		{"2022-01-05", "10", "10"}, // ex-dividend date
+		{"2022-01-06", "10", "10"},
+		{"2022-01-07", "10", "10"},
+	})
+}
+
+func TestNewDividendCaptureRejectsNonPositiveDays(t *testing.T) {
+	tests := []struct {
+		name       string
+		daysBefore int
+		daysAfter  int
+	}{
+		{name: "both zero", daysBefore: 0, daysAfter: 0},
+		{name: "before zero", daysBefore: 0, daysAfter: 1},
+		{name: "after zero", daysBefore: 1, daysAfter: 0},
+		{name: "before negative", daysBefore: -1, daysAfter: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewDividendCapture(map[string]bool{"2022-01-05": true}, tt.daysBefore, tt.daysAfter); err == nil {
+				t.Errorf("NewDividendCapture(daysBefore=%d, daysAfter=%d) = nil error, want error", tt.daysBefore, tt.daysAfter)
+			}
+		})
+	}
+}
+
+func TestDividendCaptureBuyHoldSell(t *testing.T) {
+	strat, err := NewDividendCapture(map[string]bool{"2022-01-05": true}, 2, 1)
+	if err != nil {
+		t.Fatalf("NewDividendCapture() error: %v", err)
+	}
+	if err := strat.Init(testDividendCaptureFrame()); err != nil {
+		t.Fatalf("Init() error: %v", err)
+	}
+
+	// Ex-dividend is bar 2 (2022-01-05): buy 2 bars before (bar 0), hold
+	// through bars 1-2, sell 1 bar after (bar 3).
+	want := []Signal{Buy, Hold, Hold, Sell, Hold}
+	for i, w := range want {
+		if got := strat.OnBar(i); got != w {
+			t.Errorf("OnBar(%d) = %s, want %s", i, got, w)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package strategies
+
+import (
+	"fmt"
+
+	"github.com/go-gota/gota/dataframe"
+	"github.com/go-gota/gota/series"
+)
+
+// EMACrossover buys when the short EMA crosses above the long EMA and sells
+// when it crosses back below. It reacts faster than SMACrossover since EMAs
+// weight recent bars more heavily.
+type EMACrossover struct {
+	ShortWindow int
+	LongWindow  int
+
+	shortEMA series.Series
+	longEMA  series.Series
+}
+
+func (e *EMACrossover) Init(df dataframe.DataFrame) error {
+	if df.Nrow() < e.LongWindow {
+		return fmt.Errorf("not enough data for EMA crossover: need %d rows, got %d", e.LongWindow, df.Nrow())
+	}
+	close := df.Col("CLOSE")
+	e.shortEMA = ema(close, e.ShortWindow)
+	e.longEMA = ema(close, e.LongWindow)
+	return nil
+}
+
+func (e *EMACrossover) WarmupBars() int { return e.LongWindow }
+
+func (e *EMACrossover) OnBar(i int) Signal {
+	if i < 1 {
+		return Hold
+	}
+	curShort, prevShort := e.shortEMA.Elem(i).Float(), e.shortEMA.Elem(i-1).Float()
+	curLong, prevLong := e.longEMA.Elem(i).Float(), e.longEMA.Elem(i-1).Float()
+
+	switch {
+	case curShort > curLong && prevShort <= prevLong:
+		return Buy
+	case curShort < curLong && prevShort >= prevLong:
+		return Sell
+	default:
+		return Hold
+	}
+}
@@ -0,0 +1,154 @@
+package strategies
+
+import (
+	"math"
+
+	"github.com/go-gota/gota/series"
+)
+
+// sma computes the simple moving average over window using a sliding sum.
+// Bars before the window fills are zero.
+func sma(s series.Series, window int) series.Series {
+	if s.Len() < window {
+		return series.New([]float64{}, series.Float, "SMA")
+	}
+
+	var out []float64
+	for i := 0; i < window-1; i++ {
+		out = append(out, 0.0)
+	}
+
+	var sum float64
+	for i := 0; i < window; i++ {
+		sum += s.Elem(i).Float()
+	}
+	out = append(out, sum/float64(window))
+
+	for i := window; i < s.Len(); i++ {
+		sum += s.Elem(i).Float() - s.Elem(i-window).Float()
+		out = append(out, sum/float64(window))
+	}
+
+	return series.New(out, series.Float, "SMA")
+}
+
+// ema computes the exponential moving average over window, seeded with the
+// SMA of the first window values. Bars before the window fills are zero.
+func ema(s series.Series, window int) series.Series {
+	n := s.Len()
+	out := make([]float64, n)
+	if n < window {
+		return series.New(out, series.Float, "EMA")
+	}
+
+	var sum float64
+	for i := 0; i < window; i++ {
+		sum += s.Elem(i).Float()
+	}
+	seed := sum / float64(window)
+	out[window-1] = seed
+
+	alpha := 2.0 / float64(window+1)
+	prev := seed
+	for i := window; i < n; i++ {
+		v := s.Elem(i).Float()*alpha + prev*(1-alpha)
+		out[i] = v
+		prev = v
+	}
+
+	return series.New(out, series.Float, "EMA")
+}
+
+// rsi computes the Relative Strength Index over window using Wilder's
+// smoothing of average gains and losses. Bars before the window fills are zero.
+func rsi(s series.Series, window int) series.Series {
+	n := s.Len()
+	out := make([]float64, n)
+	if n <= window {
+		return series.New(out, series.Float, "RSI")
+	}
+
+	var gainSum, lossSum float64
+	for i := 1; i <= window; i++ {
+		change := s.Elem(i).Float() - s.Elem(i-1).Float()
+		if change > 0 {
+			gainSum += change
+		} else {
+			lossSum += -change
+		}
+	}
+	avgGain := gainSum / float64(window)
+	avgLoss := lossSum / float64(window)
+	out[window] = rsiFromAvg(avgGain, avgLoss)
+
+	for i := window + 1; i < n; i++ {
+		change := s.Elem(i).Float() - s.Elem(i-1).Float()
+		gain, loss := 0.0, 0.0
+		if change > 0 {
+			gain = change
+		} else {
+			loss = -change
+		}
+		avgGain = (avgGain*float64(window-1) + gain) / float64(window)
+		avgLoss = (avgLoss*float64(window-1) + loss) / float64(window)
+		out[i] = rsiFromAvg(avgGain, avgLoss)
+	}
+
+	return series.New(out, series.Float, "RSI")
+}
+
+func rsiFromAvg(avgGain, avgLoss float64) float64 {
+	if avgLoss == 0 {
+		return 100
+	}
+	rs := avgGain / avgLoss
+	return 100 - (100 / (1 + rs))
+}
+
+// bollingerBands computes the middle SMA band and the upper/lower bands at
+// numStdDev standard deviations over window.
+func bollingerBands(s series.Series, window int, numStdDev float64) (mid, upper, lower series.Series) {
+	mid = sma(s, window)
+	n := s.Len()
+	up := make([]float64, n)
+	low := make([]float64, n)
+
+	for i := window - 1; i < n; i++ {
+		m := mid.Elem(i).Float()
+		var sumSq float64
+		for j := i - window + 1; j <= i; j++ {
+			diff := s.Elem(j).Float() - m
+			sumSq += diff * diff
+		}
+		stddev := math.Sqrt(sumSq / float64(window))
+		up[i] = m + numStdDev*stddev
+		low[i] = m - numStdDev*stddev
+	}
+
+	upper = series.New(up, series.Float, "BB_UPPER")
+	lower = series.New(low, series.Float, "BB_LOWER")
+	return mid, upper, lower
+}
+
+// atr computes the Average True Range over window as a simple rolling mean
+// of the true range across high, low and close.
+func atr(high, low, close series.Series, window int) series.Series {
+	n := close.Len()
+	tr := make([]float64, n)
+	for i := 0; i < n; i++ {
+		h, l := high.Elem(i).Float(), low.Elem(i).Float()
+		if i == 0 {
+			tr[i] = h - l
+			continue
+		}
+		prevClose := close.Elem(i - 1).Float()
+		tr[i] = math.Max(h-l, math.Max(math.Abs(h-prevClose), math.Abs(l-prevClose)))
+	}
+	return sma(series.New(tr, series.Float, "TR"), window)
+}
+
+// ATR exports atr for callers outside this package, such as volatility-based
+// position sizing, that need the same indicator without wiring up a Strategy.
+func ATR(high, low, close series.Series, window int) series.Series {
+	return atr(high, low, close, window)
+}
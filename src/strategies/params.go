@@ -0,0 +1,92 @@
+package strategies
+
+import "fmt"
+
+// Params holds every tunable knob across the strategies this package ships,
+// loaded from a YAML config file so a single binary can backtest many
+// parameterizations without recompiling. Not every strategy reads every field.
+type Params struct {
+	ShortWindow int `yaml:"short_window"`
+	LongWindow  int `yaml:"long_window"`
+
+	RSIWindow        int     `yaml:"rsi_window"`
+	RSIBuyThreshold  float64 `yaml:"rsi_buy_threshold"`
+	RSISellThreshold float64 `yaml:"rsi_sell_threshold"`
+
+	BollingerWindow    int     `yaml:"bollinger_window"`
+	BollingerNumStdDev float64 `yaml:"bollinger_num_std_dev"`
+
+	UseATRTrailingStop bool    `yaml:"use_atr_trailing_stop"`
+	ATRWindow          int     `yaml:"atr_window"`
+	TakeProfitFactor   float64 `yaml:"take_profit_factor"`
+
+	DividendCaptureDaysBefore int `yaml:"dividend_capture_days_before"`
+	DividendCaptureDaysAfter  int `yaml:"dividend_capture_days_after"`
+}
+
+// New builds the Strategy registered under name, optionally wrapped in an
+// ATRTrailingStop exit when p.UseATRTrailingStop is set.
+func New(name string, p Params) (Strategy, error) {
+	var strat Strategy
+
+	switch name {
+	case "sma_crossover":
+		if err := requirePositiveWindow("short_window", p.ShortWindow); err != nil {
+			return nil, err
+		}
+		if err := requirePositiveWindow("long_window", p.LongWindow); err != nil {
+			return nil, err
+		}
+		strat = &SMACrossover{ShortWindow: p.ShortWindow, LongWindow: p.LongWindow}
+	case "ema_crossover":
+		if err := requirePositiveWindow("short_window", p.ShortWindow); err != nil {
+			return nil, err
+		}
+		if err := requirePositiveWindow("long_window", p.LongWindow); err != nil {
+			return nil, err
+		}
+		strat = &EMACrossover{ShortWindow: p.ShortWindow, LongWindow: p.LongWindow}
+	case "rsi_mean_reversion":
+		if err := requirePositiveWindow("rsi_window", p.RSIWindow); err != nil {
+			return nil, err
+		}
+		buyThreshold, sellThreshold := p.RSIBuyThreshold, p.RSISellThreshold
+		if buyThreshold == 0 {
+			buyThreshold = 30
+		}
+		if sellThreshold == 0 {
+			sellThreshold = 70
+		}
+		strat = &RSIMeanReversion{Window: p.RSIWindow, BuyThreshold: buyThreshold, SellThreshold: sellThreshold}
+	case "bollinger_breakout":
+		if err := requirePositiveWindow("bollinger_window", p.BollingerWindow); err != nil {
+			return nil, err
+		}
+		numStdDev := p.BollingerNumStdDev
+		if numStdDev == 0 {
+			numStdDev = 2
+		}
+		strat = &BollingerBreakout{Window: p.BollingerWindow, NumStdDev: numStdDev}
+	default:
+		return nil, fmt.Errorf("unknown strategy %q", name)
+	}
+
+	if p.UseATRTrailingStop {
+		if err := requirePositiveWindow("atr_window", p.ATRWindow); err != nil {
+			return nil, err
+		}
+		strat = NewATRTrailingStop(strat, p.ATRWindow, p.TakeProfitFactor)
+	}
+
+	return strat, nil
+}
+
+// requirePositiveWindow rejects a zero or negative indicator window before
+// it can reach sma/ema/rsi/atr and silently divide by zero into an all-NaN
+// series that looks like "no signal" rather than a broken config.
+func requirePositiveWindow(field string, window int) error {
+	if window <= 0 {
+		return fmt.Errorf("%s must be positive, got %d", field, window)
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+package strategies
+
+import (
+	"fmt"
+
+	"github.com/go-gota/gota/dataframe"
+	"github.com/go-gota/gota/series"
+)
+
+// RSIMeanReversion buys when RSI drops below BuyThreshold (oversold) and
+// sells when it rises above SellThreshold (overbought).
+type RSIMeanReversion struct {
+	Window        int
+	BuyThreshold  float64
+	SellThreshold float64
+
+	rsi series.Series
+}
+
+func (r *RSIMeanReversion) Init(df dataframe.DataFrame) error {
+	if df.Nrow() <= r.Window {
+		return fmt.Errorf("not enough data for RSI mean reversion: need more than %d rows, got %d", r.Window, df.Nrow())
+	}
+	r.rsi = rsi(df.Col("CLOSE"), r.Window)
+	return nil
+}
+
+func (r *RSIMeanReversion) WarmupBars() int { return r.Window + 1 }
+
+func (r *RSIMeanReversion) OnBar(i int) Signal {
+	v := r.rsi.Elem(i).Float()
+	switch {
+	case v < r.BuyThreshold:
+		return Buy
+	case v > r.SellThreshold:
+		return Sell
+	default:
+		return Hold
+	}
+}
@@ -0,0 +1,48 @@
+package strategies
+
+import (
+	"fmt"
+
+	"github.com/go-gota/gota/dataframe"
+	"github.com/go-gota/gota/series"
+)
+
+// SMACrossover buys when the short SMA crosses above the long SMA and sells
+// when it crosses back below. This is the backtester's original built-in
+// strategy, now expressed behind the Strategy interface.
+type SMACrossover struct {
+	ShortWindow int
+	LongWindow  int
+
+	shortMA series.Series
+	longMA  series.Series
+}
+
+func (s *SMACrossover) Init(df dataframe.DataFrame) error {
+	if df.Nrow() < s.LongWindow {
+		return fmt.Errorf("not enough data for SMA crossover: need %d rows, got %d", s.LongWindow, df.Nrow())
+	}
+	close := df.Col("CLOSE")
+	s.shortMA = sma(close, s.ShortWindow)
+	s.longMA = sma(close, s.LongWindow)
+	return nil
+}
+
+func (s *SMACrossover) WarmupBars() int { return s.LongWindow }
+
+func (s *SMACrossover) OnBar(i int) Signal {
+	if i < 1 {
+		return Hold
+	}
+	curShort, prevShort := s.shortMA.Elem(i).Float(), s.shortMA.Elem(i-1).Float()
+	curLong, prevLong := s.longMA.Elem(i).Float(), s.longMA.Elem(i-1).Float()
+
+	switch {
+	case curShort > curLong && prevShort <= prevLong:
+		return Buy
+	case curShort < curLong && prevShort >= prevLong:
+		return Sell
+	default:
+		return Hold
+	}
+}
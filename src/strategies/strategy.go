@@ -0,0 +1,39 @@
+// Package strategies implements pluggable backtest entry/exit rules behind a
+// common interface, so the backtester can run many parameterizations without
+// hard-coding a single crossover rule.
+package strategies
+
+import "github.com/go-gota/gota/dataframe"
+
+// Signal is the trading action a Strategy emits for a given bar.
+type Signal int
+
+const (
+	Hold Signal = iota
+	Buy
+	Sell
+)
+
+func (s Signal) String() string {
+	switch s {
+	case Buy:
+		return "Buy"
+	case Sell:
+		return "Sell"
+	default:
+		return "Hold"
+	}
+}
+
+// Strategy decides, bar by bar, whether to enter or exit a position. Init
+// must be called once with the full price history before OnBar is called
+// for any bar index.
+type Strategy interface {
+	// Init prepares the strategy's indicators from df.
+	Init(df dataframe.DataFrame) error
+	// OnBar returns the signal for bar index i.
+	OnBar(i int) Signal
+	// WarmupBars is the number of leading bars OnBar cannot be trusted for,
+	// because the underlying indicators haven't filled their window yet.
+	WarmupBars() int
+}